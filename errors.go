@@ -0,0 +1,61 @@
+package esquel
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IsRetryable reports whether err looks like a transient serialization
+// failure or deadlock that's worth retrying in a fresh transaction. It
+// recognizes Postgres (40001, 40P01) and MySQL (1213, 1205) error codes
+// by duck typing a "Code" or "Number" field on err, the shape exposed by
+// lib/pq's Error, pgx's PgError and go-sql-driver/mysql's MySQLError,
+// without depending on any driver package, plus SQLite's busy message.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if code, ok := driverErrorCode(err); ok {
+		switch code {
+		case "40001", "40P01", "1213", "1205":
+			return true
+		}
+	}
+
+	msg := err.Error()
+
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "SQLITE_BUSY")
+}
+
+// driverErrorCode extracts a Postgres/MySQL style error code from err by
+// looking for a "Code" or "Number" field on the underlying struct.
+func driverErrorCode(err error) (string, bool) {
+	v := reflect.ValueOf(err)
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+
+	if f := v.FieldByName("Code"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String(), true
+	}
+
+	if f := v.FieldByName("Number"); f.IsValid() {
+		switch f.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(f.Uint(), 10), true
+		}
+	}
+
+	return "", false
+}
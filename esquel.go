@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
@@ -436,6 +437,30 @@ type Query[T any, P any] struct {
 	Placeholder Placeholder
 	Statement   Statement[P]
 	Columns     map[string]Scanner[T]
+	Hooks       Hooks
+	Loaders     []Loader[T]
+}
+
+// With returns a copy of q that runs loaders after All, First or One
+// materializes the result, in addition to any loaders q already has.
+func (q Query[T, P]) With(loaders ...Loader[T]) Query[T, P] {
+	q.Loaders = append(append([]Loader[T]{}, q.Loaders...), loaders...)
+
+	return q
+}
+
+func (q Query[T, P]) load(ctx context.Context, querier Querier, list []T) error {
+	for _, loader := range q.Loaders {
+		if loader == nil {
+			continue
+		}
+
+		if err := loader.Load(ctx, querier, list); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (q Query[T, P]) Rows(ctx context.Context, querier Querier, param P) (*Rows[T], error) {
@@ -451,7 +476,25 @@ func (q Query[T, P]) Rows(ctx context.Context, querier Querier, param P) (*Rows[
 		}
 	}
 
+	hooks := q.Hooks
+	if hooks == nil {
+		hooks = DefaultHooks
+	}
+
+	if hooks != nil {
+		ctx, err = hooks.BeforeQuery(ctx, sql, args)
+		if err != nil {
+			hooks.AfterQuery(ctx, sql, args, nil, err)
+
+			return nil, err
+		}
+	}
+
 	rows, err := querier.QueryContext(ctx, sql, args...)
+
+	if hooks != nil {
+		hooks.AfterQuery(ctx, sql, args, rows, err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -466,6 +509,47 @@ func (q Query[T, P]) Rows(ctx context.Context, querier Querier, param P) (*Rows[
 	if len(q.Columns) == 0 {
 		var v T
 
+		if rt := structType(reflect.TypeOf(v)); rt != nil {
+			fields := structFieldsByTag(rt, "db")
+			isPtr := reflect.TypeOf(v).Kind() == reflect.Ptr
+
+			// Rebuild scratch and Dest's addresses before each row, or
+			// rows reached through a pointer field would all alias the
+			// same allocation.
+			var scratch reflect.Value
+
+			reset := func() {
+				scratch = reflect.New(rt).Elem()
+
+				for i, c := range columns {
+					if index, ok := fields[c]; ok {
+						dest[i] = fieldAddrByIndex(scratch, index).Interface()
+					} else {
+						dest[i] = new(any)
+					}
+				}
+			}
+
+			reset()
+
+			return &Rows[T]{
+				Rows:  rows,
+				Dest:  dest,
+				Reset: reset,
+				Map: func(t *T) error {
+					if isPtr {
+						ptr := reflect.New(rt)
+						ptr.Elem().Set(scratch)
+						*t = ptr.Interface().(T)
+					} else {
+						*t = scratch.Interface().(T)
+					}
+
+					return nil
+				},
+			}, nil
+		}
+
 		dest[0] = &v
 
 		for i := range dest {
@@ -489,7 +573,11 @@ func (q Query[T, P]) Rows(ctx context.Context, querier Querier, param P) (*Rows[
 
 	for i, c := range columns {
 		if s, ok := q.Columns[c]; ok && s != nil {
-			dest[i], mappers[i] = s.Scan()
+			if ns, ok := s.(namedScanner[T]); ok {
+				dest[i], mappers[i] = ns.scanNamed(c)
+			} else {
+				dest[i], mappers[i] = s.Scan()
+			}
 		} else {
 			dest[i] = new(any)
 		}
@@ -518,7 +606,16 @@ func (q Query[T, P]) All(ctx context.Context, querier Querier, param P) ([]T, er
 		return nil, err
 	}
 
-	return rows.All()
+	list, err := rows.All()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := q.load(ctx, querier, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
 }
 
 func (q Query[T, P]) First(ctx context.Context, querier Querier, param P) (T, error) {
@@ -529,7 +626,17 @@ func (q Query[T, P]) First(ctx context.Context, querier Querier, param P) (T, er
 		return t, err
 	}
 
-	return rows.First()
+	t, err = rows.First()
+	if err != nil {
+		return t, err
+	}
+
+	list := []T{t}
+	if err := q.load(ctx, querier, list); err != nil {
+		return t, err
+	}
+
+	return list[0], nil
 }
 
 func (q Query[T, P]) One(ctx context.Context, querier Querier, param P) (T, error) {
@@ -540,13 +647,26 @@ func (q Query[T, P]) One(ctx context.Context, querier Querier, param P) (T, erro
 		return t, err
 	}
 
-	return rows.One()
+	t, err = rows.One()
+	if err != nil {
+		return t, err
+	}
+
+	list := []T{t}
+	if err := q.load(ctx, querier, list); err != nil {
+		return t, err
+	}
+
+	return list[0], nil
 }
 
 type Rows[T any] struct {
 	Rows *sql.Rows
 	Dest []any
 	Map  func(*T) error
+
+	// Reset, if set, runs before every row is scanned into Dest.
+	Reset func()
 }
 
 func (r *Rows[T]) Next() bool {
@@ -558,6 +678,10 @@ func (r *Rows[T]) Scan(t *T) error {
 		return sql.ErrNoRows
 	}
 
+	if r.Reset != nil {
+		r.Reset()
+	}
+
 	if err := r.Rows.Scan(r.Dest...); err != nil {
 		return err
 	}
@@ -680,6 +804,7 @@ func (r *Rows[T]) One() (T, error) {
 type Exec[P any] struct {
 	Placeholder Placeholder
 	Statement   Statement[P]
+	Hooks       Hooks
 }
 
 func (es Exec[P]) Result(ctx context.Context, executor Executor, param P) (sql.Result, error) {
@@ -695,5 +820,25 @@ func (es Exec[P]) Result(ctx context.Context, executor Executor, param P) (sql.R
 		}
 	}
 
-	return executor.ExecContext(ctx, sql, args...)
+	hooks := es.Hooks
+	if hooks == nil {
+		hooks = DefaultHooks
+	}
+
+	if hooks != nil {
+		ctx, err = hooks.BeforeExec(ctx, sql, args)
+		if err != nil {
+			hooks.AfterExec(ctx, sql, args, nil, err)
+
+			return nil, err
+		}
+	}
+
+	result, err := executor.ExecContext(ctx, sql, args...)
+
+	if hooks != nil {
+		hooks.AfterExec(ctx, sql, args, result, err)
+	}
+
+	return result, err
 }
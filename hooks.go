@@ -0,0 +1,20 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Hooks observes the queries and execs a Query[T, P] or Exec[P] runs. A
+// Before hook can derive a new context and abort the call with an error;
+// the matching After hook always runs with the call's final error.
+type Hooks interface {
+	BeforeQuery(ctx context.Context, sql string, args []any) (context.Context, error)
+	AfterQuery(ctx context.Context, sql string, args []any, rows *sql.Rows, err error)
+	BeforeExec(ctx context.Context, sql string, args []any) (context.Context, error)
+	AfterExec(ctx context.Context, sql string, args []any, result sql.Result, err error)
+}
+
+// DefaultHooks is used by Query[T, P] and Exec[P] values whose own Hooks
+// field is nil.
+var DefaultHooks Hooks
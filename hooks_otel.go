@@ -0,0 +1,71 @@
+//go:build esquel_otel
+
+package esquel
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OTelHooks is a Hooks implementation that opens one OpenTelemetry span
+// per query/exec, recording the error (if any) before ending the span.
+// Building with this file requires the esquel_otel build tag and
+// go.opentelemetry.io/otel in go.mod, so the core module stays
+// dependency-free for everyone else.
+type OTelHooks struct {
+	Tracer trace.Tracer
+}
+
+func (h OTelHooks) tracer() trace.Tracer {
+	if h.Tracer != nil {
+		return h.Tracer
+	}
+
+	return otel.Tracer("github.com/wroge/esquel")
+}
+
+type otelSpanKey struct{}
+
+func (h OTelHooks) BeforeQuery(ctx context.Context, sql string, args []any) (context.Context, error) {
+	return h.start(ctx, "esquel.Query", sql, args), nil
+}
+
+func (h OTelHooks) AfterQuery(ctx context.Context, sql string, args []any, rows *sql.Rows, err error) {
+	h.end(ctx, err)
+}
+
+func (h OTelHooks) BeforeExec(ctx context.Context, sql string, args []any) (context.Context, error) {
+	return h.start(ctx, "esquel.Exec", sql, args), nil
+}
+
+func (h OTelHooks) AfterExec(ctx context.Context, sql string, args []any, result sql.Result, err error) {
+	h.end(ctx, err)
+}
+
+func (h OTelHooks) start(ctx context.Context, name, sql string, args []any) context.Context {
+	ctx, span := h.tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String("db.statement", sql),
+		attribute.Int("db.args", len(args)),
+	))
+
+	return context.WithValue(ctx, otelSpanKey{}, span)
+}
+
+func (h OTelHooks) end(ctx context.Context, err error) {
+	span, ok := ctx.Value(otelSpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,69 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+)
+
+// SlogHooks is a Hooks implementation that logs each query and exec via
+// log/slog, with the SQL, argument count, and duration.
+type SlogHooks struct {
+	Logger *slog.Logger
+	Level  slog.Level
+}
+
+func (h SlogHooks) logger() *slog.Logger {
+	if h.Logger != nil {
+		return h.Logger
+	}
+
+	return slog.Default()
+}
+
+type slogStartTimeKey struct{}
+
+func (h SlogHooks) BeforeQuery(ctx context.Context, sql string, args []any) (context.Context, error) {
+	return context.WithValue(ctx, slogStartTimeKey{}, time.Now()), nil
+}
+
+func (h SlogHooks) AfterQuery(ctx context.Context, sql string, args []any, rows *sql.Rows, err error) {
+	h.logger().LogAttrs(ctx, h.Level, "esquel: query",
+		slog.String("sql", sql),
+		slog.Int("args", len(args)),
+		slog.Duration("duration", h.elapsed(ctx)),
+		slog.Any("error", err),
+	)
+}
+
+func (h SlogHooks) BeforeExec(ctx context.Context, sql string, args []any) (context.Context, error) {
+	return context.WithValue(ctx, slogStartTimeKey{}, time.Now()), nil
+}
+
+func (h SlogHooks) AfterExec(ctx context.Context, sql string, args []any, result sql.Result, err error) {
+	attrs := []slog.Attr{
+		slog.String("sql", sql),
+		slog.Int("args", len(args)),
+		slog.Duration("duration", h.elapsed(ctx)),
+	}
+
+	if err == nil && result != nil {
+		if n, rerr := result.RowsAffected(); rerr == nil {
+			attrs = append(attrs, slog.Int64("rows_affected", n))
+		}
+	}
+
+	attrs = append(attrs, slog.Any("error", err))
+
+	h.logger().LogAttrs(ctx, h.Level, "esquel: exec", attrs...)
+}
+
+func (h SlogHooks) elapsed(ctx context.Context) time.Duration {
+	start, ok := ctx.Value(slogStartTimeKey{}).(time.Time)
+	if !ok {
+		return 0
+	}
+
+	return time.Since(start)
+}
@@ -0,0 +1,147 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+type hookCall struct {
+	kind string // "before-query", "after-query", "before-exec", "after-exec"
+	err  error
+}
+
+type recordingHooksKey struct{}
+
+// recordingHooks implements Hooks and records the order of every
+// Before/After call and the error each After call receives, optionally
+// failing BeforeQuery/BeforeExec.
+type recordingHooks struct {
+	calls     *[]hookCall
+	beforeErr error
+}
+
+func (h recordingHooks) BeforeQuery(ctx context.Context, sql string, args []any) (context.Context, error) {
+	*h.calls = append(*h.calls, hookCall{kind: "before-query"})
+
+	if h.beforeErr != nil {
+		return ctx, h.beforeErr
+	}
+
+	return context.WithValue(ctx, recordingHooksKey{}, "threaded"), nil
+}
+
+func (h recordingHooks) AfterQuery(ctx context.Context, sql string, args []any, rows *sql.Rows, err error) {
+	*h.calls = append(*h.calls, hookCall{kind: "after-query", err: err})
+}
+
+func (h recordingHooks) BeforeExec(ctx context.Context, sql string, args []any) (context.Context, error) {
+	*h.calls = append(*h.calls, hookCall{kind: "before-exec"})
+
+	if h.beforeErr != nil {
+		return ctx, h.beforeErr
+	}
+
+	return ctx, nil
+}
+
+func (h recordingHooks) AfterExec(ctx context.Context, sql string, args []any, result sql.Result, err error) {
+	*h.calls = append(*h.calls, hookCall{kind: "after-exec", err: err})
+}
+
+func TestHooksFireAroundSuccessfulQuery(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "name"},
+		data: [][]driver.Value{{int64(1), "a"}},
+	})
+
+	var calls []hookCall
+
+	q := Query[scanItem, any]{
+		Statement: selectAllStatement(),
+		Hooks:     recordingHooks{calls: &calls},
+	}
+
+	if _, err := q.All(context.Background(), db, nil); err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	want := []hookCall{{kind: "before-query"}, {kind: "after-query"}}
+	assertCalls(t, calls, want)
+}
+
+func TestHooksFireAfterBeforeQueryError(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{cols: []string{"id"}})
+
+	var calls []hookCall
+
+	wantErr := errors.New("boom")
+
+	q := Query[scanItem, any]{
+		Statement: selectAllStatement(),
+		Hooks:     recordingHooks{calls: &calls, beforeErr: wantErr},
+	}
+
+	_, err := q.Rows(context.Background(), db, nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	want := []hookCall{{kind: "before-query"}, {kind: "after-query", err: wantErr}}
+	assertCalls(t, calls, want)
+}
+
+func TestHooksFireAroundSuccessfulExec(t *testing.T) {
+	db, _ := openTxFakeDB(t)
+
+	var calls []hookCall
+
+	es := Exec[any]{
+		Statement: Expr(func(any) (string, []any, error) { return "DELETE FROM items", nil, nil }),
+		Hooks:     recordingHooks{calls: &calls},
+	}
+
+	if _, err := es.Result(context.Background(), db, nil); err != nil {
+		t.Fatalf("Result: %v", err)
+	}
+
+	want := []hookCall{{kind: "before-exec"}, {kind: "after-exec"}}
+	assertCalls(t, calls, want)
+}
+
+func TestHooksFireAfterBeforeExecError(t *testing.T) {
+	db, _ := openTxFakeDB(t)
+
+	var calls []hookCall
+
+	wantErr := errors.New("boom")
+
+	es := Exec[any]{
+		Statement: Expr(func(any) (string, []any, error) { return "DELETE FROM items", nil, nil }),
+		Hooks:     recordingHooks{calls: &calls, beforeErr: wantErr},
+	}
+
+	_, err := es.Result(context.Background(), db, nil)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	want := []hookCall{{kind: "before-exec"}, {kind: "after-exec", err: wantErr}}
+	assertCalls(t, calls, want)
+}
+
+func assertCalls(t *testing.T, got, want []hookCall) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("calls = %+v, want %+v", got, want)
+	}
+
+	for i := range want {
+		if got[i].kind != want[i].kind || !errors.Is(got[i].err, want[i].err) {
+			t.Errorf("call %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package esquel
+
+import "strings"
+
+// In returns a Statement that rewrites the single ? placeholder in sql
+// into one ? per element of the slice get returns, mirroring sqlx.In. An
+// empty slice renders NULL instead of an empty "()".
+func In[P any](sql string, get func(P) []any) Statement[P] {
+	return inStatement[P]{
+		sql: sql,
+		get: get,
+	}
+}
+
+// InSlice is a convenience wrapper around In for a typed slice, avoiding
+// the []any conversion at the call site.
+func InSlice[P any, V any](sql string, get func(P) []V) Statement[P] {
+	return In(sql, func(param P) []any {
+		values := get(param)
+
+		args := make([]any, len(values))
+		for i, v := range values {
+			args[i] = v
+		}
+
+		return args
+	})
+}
+
+type inStatement[P any] struct {
+	sql string
+	get func(P) []any
+}
+
+func (in inStatement[P]) ToSQL(param P) (string, []any, error) {
+	values := in.get(param)
+
+	if len(values) == 0 {
+		return replacePlaceholder(in.sql, "NULL"), nil, nil
+	}
+
+	return replacePlaceholder(in.sql, strings.Repeat(",?", len(values))[1:]), values, nil
+}
+
+// replacePlaceholder replaces the first unescaped ? in sql with
+// replacement; a doubled ?? before it collapses to a literal ?, and the
+// text after it is copied unchanged.
+func replacePlaceholder(sql, replacement string) string {
+	var builder strings.Builder
+
+	for {
+		index := strings.IndexByte(sql, '?')
+		if index < 0 {
+			builder.WriteString(sql)
+
+			return builder.String()
+		}
+
+		if index < len(sql)-1 && sql[index+1] == '?' {
+			builder.WriteString(sql[:index+1])
+			sql = sql[index+2:]
+
+			continue
+		}
+
+		builder.WriteString(sql[:index])
+		builder.WriteString(replacement)
+		builder.WriteString(sql[index+1:])
+
+		return builder.String()
+	}
+}
@@ -0,0 +1,74 @@
+package esquel
+
+import "testing"
+
+func TestInExpandsPlaceholderPerElement(t *testing.T) {
+	stmt := In[[]int]("id IN (?)", func(ids []int) []any {
+		args := make([]any, len(ids))
+		for i, id := range ids {
+			args[i] = id
+		}
+
+		return args
+	})
+
+	sql, args, err := stmt.ToSQL([]int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "id IN (?,?,?)" {
+		t.Errorf("sql = %q, want %q", sql, "id IN (?,?,?)")
+	}
+
+	if len(args) != 3 || args[0] != 1 || args[1] != 2 || args[2] != 3 {
+		t.Errorf("args = %v, want [1 2 3]", args)
+	}
+}
+
+func TestInEmptySliceRendersNULL(t *testing.T) {
+	stmt := InSlice[[]int, int]("id IN (?)", func(ids []int) []int { return ids })
+
+	sql, args, err := stmt.ToSQL(nil)
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "id IN (NULL)" {
+		t.Errorf("sql = %q, want %q", sql, "id IN (NULL)")
+	}
+
+	if len(args) != 0 {
+		t.Errorf("args = %v, want none", args)
+	}
+}
+
+func TestInSliceConvertsTypedElements(t *testing.T) {
+	stmt := InSlice[[]string, string]("name IN (?)", func(names []string) []string { return names })
+
+	sql, args, err := stmt.ToSQL([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "name IN (?,?)" {
+		t.Errorf("sql = %q, want %q", sql, "name IN (?,?)")
+	}
+
+	if len(args) != 2 || args[0] != "a" || args[1] != "b" {
+		t.Errorf("args = %v, want [a b]", args)
+	}
+}
+
+func TestInCollapsesDoubledPlaceholderEscape(t *testing.T) {
+	stmt := InSlice[[]int, int]("q(??, ?)", func(ids []int) []int { return ids })
+
+	sql, _, err := stmt.ToSQL([]int{1, 2})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "q(?, ?,?)" {
+		t.Errorf("sql = %q, want %q", sql, "q(?, ?,?)")
+	}
+}
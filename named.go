@@ -0,0 +1,219 @@
+package esquel
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Named returns a Statement whose SQL carries named markers such as
+// :user_id or @name instead of positional ?. Each extractor contributes
+// one name/value pair for the param; a marker repeated in the SQL reuses
+// the same extracted value. Unlike sqlx's ":: escapes a literal colon",
+// a doubled colon is left as-is so Postgres casts like amount::numeric
+// keep working.
+func Named[P any](sql string, extractors ...func(P) (string, any)) Statement[P] {
+	rewritten, names := parseNamedSQL(sql)
+
+	return namedStatement[P]{
+		sql:        rewritten,
+		names:      names,
+		extractors: extractors,
+	}
+}
+
+type namedStatement[P any] struct {
+	sql        string
+	names      []string
+	extractors []func(P) (string, any)
+}
+
+func (n namedStatement[P]) ToSQL(param P) (string, []any, error) {
+	values := make(map[string]any, len(n.extractors))
+
+	for _, extract := range n.extractors {
+		name, value := extract(param)
+		values[name] = value
+	}
+
+	args, err := resolveNamedArgs(n.names, func(name string) (any, bool) {
+		value, ok := values[name]
+
+		return value, ok
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return n.sql, args, nil
+}
+
+// NamedFromStruct returns a Statement that resolves :name/@name markers
+// from P's fields via their db tags (or snake_case of the field name). P
+// may be a struct or a pointer to one.
+func NamedFromStruct[P any](sql string) Statement[P] {
+	rewritten, names := parseNamedSQL(sql)
+
+	return namedStructStatement[P]{
+		sql:   rewritten,
+		names: names,
+	}
+}
+
+type namedStructStatement[P any] struct {
+	sql   string
+	names []string
+}
+
+func (n namedStructStatement[P]) ToSQL(param P) (string, []any, error) {
+	v := reflect.ValueOf(param)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+
+	fields := structFieldsByTag(v.Type(), "db")
+
+	args, err := resolveNamedArgs(n.names, func(name string) (any, bool) {
+		index, ok := fields[name]
+		if !ok {
+			return nil, false
+		}
+
+		if fv := fieldByIndex(v, index); fv.IsValid() {
+			return fv.Interface(), true
+		}
+
+		return nil, true
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return n.sql, args, nil
+}
+
+// NamedFromMap returns a Statement that resolves :name/@name markers by
+// looking them up in a map[string]any.
+func NamedFromMap(sql string) Statement[map[string]any] {
+	rewritten, names := parseNamedSQL(sql)
+
+	return namedMapStatement{
+		sql:   rewritten,
+		names: names,
+	}
+}
+
+type namedMapStatement struct {
+	sql   string
+	names []string
+}
+
+func (n namedMapStatement) ToSQL(param map[string]any) (string, []any, error) {
+	args, err := resolveNamedArgs(n.names, func(name string) (any, bool) {
+		value, ok := param[name]
+
+		return value, ok
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return n.sql, args, nil
+}
+
+func resolveNamedArgs(names []string, lookup func(name string) (any, bool)) ([]any, error) {
+	args := make([]any, len(names))
+
+	for i, name := range names {
+		value, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("esquel: unknown named parameter %q", name)
+		}
+
+		args[i] = value
+	}
+
+	return args, nil
+}
+
+// parseNamedSQL rewrites :name and @name markers into ? placeholders in
+// appearance order, returning the rewritten SQL and the ordered marker
+// names. Quoted literals are skipped verbatim and :: passes through
+// unchanged.
+func parseNamedSQL(sql string) (string, []string) {
+	var (
+		builder strings.Builder
+		names   []string
+	)
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		if c == '\'' || c == '"' {
+			j := quotedLiteralEnd(sql, i, c)
+
+			builder.WriteString(sql[i:j])
+			i = j - 1
+
+			continue
+		}
+
+		if c != ':' && c != '@' {
+			builder.WriteByte(c)
+
+			continue
+		}
+
+		if c == ':' && i+1 < len(sql) && sql[i+1] == ':' {
+			builder.WriteByte(':')
+			builder.WriteByte(':')
+			i++
+
+			continue
+		}
+
+		start := i + 1
+
+		j := start
+		for j < len(sql) && isNameByte(sql[j]) {
+			j++
+		}
+
+		if j == start {
+			builder.WriteByte(c)
+
+			continue
+		}
+
+		builder.WriteByte('?')
+		names = append(names, sql[start:j])
+		i = j - 1
+	}
+
+	return builder.String(), names
+}
+
+func isNameByte(b byte) bool {
+	return b == '_' || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z') || ('0' <= b && b <= '9')
+}
+
+// quotedLiteralEnd returns the index just past the quoted literal
+// starting at sql[start]; a doubled quote escapes a literal quote. If
+// the literal is never closed, it returns len(sql).
+func quotedLiteralEnd(sql string, start int, quote byte) int {
+	for j := start + 1; j < len(sql); j++ {
+		if sql[j] != quote {
+			continue
+		}
+
+		if j+1 < len(sql) && sql[j+1] == quote {
+			j++
+
+			continue
+		}
+
+		return j + 1
+	}
+
+	return len(sql)
+}
@@ -0,0 +1,149 @@
+package esquel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseNamedSQL(t *testing.T) {
+	cases := []struct {
+		name      string
+		sql       string
+		wantSQL   string
+		wantNames []string
+	}{
+		{
+			name:      "basic marker",
+			sql:       "WHERE id = :id",
+			wantSQL:   "WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "repeated marker kept in appearance order",
+			sql:       "WHERE a = :x OR b = :x",
+			wantSQL:   "WHERE a = ? OR b = ?",
+			wantNames: []string{"x", "x"},
+		},
+		{
+			name:      "at-style marker",
+			sql:       "WHERE id = @id",
+			wantSQL:   "WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "doubled colon passes through, not collapsed",
+			sql:       "SELECT amount::numeric WHERE id = :id",
+			wantSQL:   "SELECT amount::numeric WHERE id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "colon inside a single-quoted literal is not a marker",
+			sql:       "WHERE created_at = '12:30:00' AND id = :id",
+			wantSQL:   "WHERE created_at = '12:30:00' AND id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "colon inside a double-quoted identifier is not a marker",
+			sql:       `WHERE "weird:col" = :id`,
+			wantSQL:   `WHERE "weird:col" = ?`,
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "escaped quote inside a literal doesn't end it early",
+			sql:       "WHERE name = 'O''Brien:x' AND id = :id",
+			wantSQL:   "WHERE name = 'O''Brien:x' AND id = ?",
+			wantNames: []string{"id"},
+		},
+		{
+			name:      "bare colon with no name is left untouched",
+			sql:       "WHERE id = : ",
+			wantSQL:   "WHERE id = : ",
+			wantNames: nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			gotSQL, gotNames := parseNamedSQL(c.sql)
+			if gotSQL != c.wantSQL {
+				t.Errorf("sql = %q, want %q", gotSQL, c.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotNames, c.wantNames) {
+				t.Errorf("names = %v, want %v", gotNames, c.wantNames)
+			}
+		})
+	}
+}
+
+func TestNamedReusesRepeatedMarkerValue(t *testing.T) {
+	stmt := Named[map[string]int]("WHERE a = :x OR b = :x", func(p map[string]int) (string, any) {
+		return "x", p["x"]
+	})
+
+	sql, args, err := stmt.ToSQL(map[string]int{"x": 7})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "WHERE a = ? OR b = ?" {
+		t.Errorf("sql = %q", sql)
+	}
+
+	if !reflect.DeepEqual(args, []any{7, 7}) {
+		t.Errorf("args = %v, want [7 7]", args)
+	}
+}
+
+func TestNamedUnknownNameError(t *testing.T) {
+	stmt := Named[map[string]int]("WHERE a = :x")
+
+	_, _, err := stmt.ToSQL(map[string]int{})
+	if err == nil {
+		t.Fatal("expected an error for an unresolved marker")
+	}
+
+	const want = `esquel: unknown named parameter "x"`
+	if err.Error() != want {
+		t.Errorf("err = %q, want %q", err.Error(), want)
+	}
+}
+
+type namedPerson struct {
+	ID   int `db:"id"`
+	Name string
+}
+
+func TestNamedFromStructResolvesByTagAndSnakeCase(t *testing.T) {
+	stmt := NamedFromStruct[namedPerson]("WHERE id = :id AND name = :name")
+
+	sql, args, err := stmt.ToSQL(namedPerson{ID: 1, Name: "Ada"})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "WHERE id = ? AND name = ?" {
+		t.Errorf("sql = %q", sql)
+	}
+
+	if !reflect.DeepEqual(args, []any{1, "Ada"}) {
+		t.Errorf("args = %v, want [1 Ada]", args)
+	}
+}
+
+func TestNamedFromMap(t *testing.T) {
+	stmt := NamedFromMap("WHERE id = :id")
+
+	sql, args, err := stmt.ToSQL(map[string]any{"id": 42})
+	if err != nil {
+		t.Fatalf("ToSQL: %v", err)
+	}
+
+	if sql != "WHERE id = ?" {
+		t.Errorf("sql = %q", sql)
+	}
+
+	if !reflect.DeepEqual(args, []any{42}) {
+		t.Errorf("args = %v, want [42]", args)
+	}
+}
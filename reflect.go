@@ -0,0 +1,145 @@
+package esquel
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var fieldCache sync.Map // map[fieldCacheKey]map[string][]int
+
+// fieldCacheKey keys fieldCache by struct type and tag, since the same
+// type can be collected under different tags.
+type fieldCacheKey struct {
+	t   reflect.Type
+	tag string
+}
+
+// structFieldsByTag maps tag name (or snake_case field name when untagged)
+// to the field's index path, flattening embedded structs. Cached per type and tag.
+func structFieldsByTag(t reflect.Type, tag string) map[string][]int {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	key := fieldCacheKey{t: t, tag: tag}
+
+	if cached, ok := fieldCache.Load(key); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := make(map[string][]int)
+	collectFields(t, tag, nil, fields)
+
+	actual, _ := fieldCache.LoadOrStore(key, fields)
+
+	return actual.(map[string][]int)
+}
+
+func collectFields(t reflect.Type, tag string, prefix []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		index := make([]int, len(prefix), len(prefix)+1)
+		copy(index, prefix)
+		index = append(index, i)
+
+		name, tagged := field.Tag.Lookup(tag)
+		if tagged {
+			name, _, _ = strings.Cut(name, ",")
+		}
+
+		if name == "-" {
+			continue
+		}
+
+		elem := field.Type
+		for elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+
+		if field.Anonymous && elem.Kind() == reflect.Struct && !tagged {
+			collectFields(elem, tag, index, fields)
+
+			continue
+		}
+
+		if name == "" {
+			name = toSnakeCase(field.Name)
+		}
+
+		fields[name] = index
+	}
+}
+
+func toSnakeCase(name string) string {
+	var b strings.Builder
+
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+
+			b.WriteRune(r - 'A' + 'a')
+		} else {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// fieldByIndex reads the field at index, following pointers without
+// allocating; it returns the zero Value if one is nil.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v
+}
+
+// fieldAddrByIndex returns an addressable field at index, allocating nil
+// pointers along the path as needed.
+func fieldAddrByIndex(v reflect.Value, index []int) reflect.Value {
+	for _, i := range index {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(i)
+	}
+
+	return v.Addr()
+}
+
+// fieldTypeByIndex returns the type of the field at index, dereferencing
+// pointers along the path.
+func fieldTypeByIndex(t reflect.Type, index []int) reflect.Type {
+	for _, i := range index {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		t = t.Field(i).Type
+	}
+
+	return t
+}
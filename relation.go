@@ -0,0 +1,62 @@
+package esquel
+
+import "context"
+
+// Loader runs after a Query[T, P]'s All, First or One call materializes
+// its result, so relations can be eager-loaded with a single follow-up
+// query instead of per-parent lookups or joins.
+type Loader[T any] interface {
+	Load(ctx context.Context, querier Querier, parents []T) error
+}
+
+// Relation eager-loads Child rows for a batch of Parent rows with one
+// follow-up query, avoiding N+1 lookups. Query selects the children for
+// a set of keys, typically via a Statement[[]Key] built with In or
+// InSlice; ParentKey and ChildKey extract the join key from each side,
+// and Assign stitches the grouped children back onto their parent.
+type Relation[Parent any, Child any, Key comparable] struct {
+	Query     Query[Child, []Key]
+	ParentKey func(*Parent) Key
+	ChildKey  func(Child) Key
+	Assign    func(*Parent, []Child)
+}
+
+func (r Relation[Parent, Child, Key]) Load(ctx context.Context, querier Querier, parents []Parent) error {
+	if len(parents) == 0 {
+		return nil
+	}
+
+	var (
+		keys   = make([]Key, len(parents))
+		unique []Key
+		seen   = make(map[Key]bool, len(parents))
+	)
+
+	for i := range parents {
+		key := r.ParentKey(&parents[i])
+		keys[i] = key
+
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, key)
+		}
+	}
+
+	children, err := r.Query.All(ctx, querier, unique)
+	if err != nil {
+		return err
+	}
+
+	grouped := make(map[Key][]Child, len(unique))
+
+	for _, child := range children {
+		key := r.ChildKey(child)
+		grouped[key] = append(grouped[key], child)
+	}
+
+	for i := range parents {
+		r.Assign(&parents[i], grouped[keys[i]])
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+type relChild struct {
+	ParentID int    `db:"parent_id"`
+	Name     string `db:"name"`
+}
+
+type relParent struct {
+	ID       int
+	Children []relChild
+}
+
+func childrenRelation() Relation[relParent, relChild, int] {
+	return Relation[relParent, relChild, int]{
+		Query: Query[relChild, []int]{
+			Statement: Expr(func([]int) (string, []any, error) {
+				return "SELECT parent_id, name FROM children", nil, nil
+			}),
+		},
+		ParentKey: func(p *relParent) int { return p.ID },
+		ChildKey:  func(c relChild) int { return c.ParentID },
+		Assign:    func(p *relParent, cs []relChild) { p.Children = cs },
+	}
+}
+
+func TestRelationLoadGroupsChildrenByKey(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"parent_id", "name"},
+		data: [][]driver.Value{
+			{int64(1), "a"},
+			{int64(1), "b"},
+			{int64(2), "c"},
+			{int64(3), "orphan"},
+		},
+	})
+
+	parents := []relParent{{ID: 1}, {ID: 2}, {ID: 1}}
+
+	if err := childrenRelation().Load(context.Background(), db, parents); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if len(parents[0].Children) != 2 || parents[0].Children[0].Name != "a" || parents[0].Children[1].Name != "b" {
+		t.Errorf("parents[0].Children = %+v, want [a b]", parents[0].Children)
+	}
+
+	if len(parents[1].Children) != 1 || parents[1].Children[0].Name != "c" {
+		t.Errorf("parents[1].Children = %+v, want [c]", parents[1].Children)
+	}
+
+	if len(parents[2].Children) != 2 || parents[2].Children[0].Name != "a" {
+		t.Errorf("parents[2].Children = %+v, want the same group as parents[0]", parents[2].Children)
+	}
+}
+
+func TestRelationLoadAssignsEmptySliceForNoMatch(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"parent_id", "name"},
+		data: [][]driver.Value{{int64(1), "a"}},
+	})
+
+	parents := []relParent{{ID: 1}, {ID: 2}}
+
+	if err := childrenRelation().Load(context.Background(), db, parents); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if len(parents[1].Children) != 0 {
+		t.Errorf("parents[1].Children = %+v, want none", parents[1].Children)
+	}
+}
+
+type panicQuerier struct{}
+
+func (panicQuerier) QueryContext(ctx context.Context, sql string, args ...any) (*sql.Rows, error) {
+	panic("QueryContext should not be called for an empty parents slice")
+}
+
+func TestRelationLoadSkipsQueryForNoParents(t *testing.T) {
+	if err := childrenRelation().Load(context.Background(), panicQuerier{}, nil); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package esquel
+
+import "reflect"
+
+// structScanner resolves its destination by matching a column name
+// against T's db tags, the same lookup Query[T, P] applies automatically
+// when Columns is empty.
+type structScanner[T any] struct{}
+
+// StructScanner opts one entry of a partial Columns map into struct-tag
+// based scanning; the rest keep whatever Scanner they were assigned.
+func StructScanner[T any]() Scanner[T] {
+	return structScanner[T]{}
+}
+
+func (structScanner[T]) Scan() (any, func(*T) error) {
+	var discard any
+
+	return &discard, func(*T) error { return nil }
+}
+
+// namedScanner is implemented by Scanner[T] values that need the column
+// name they were registered under.
+type namedScanner[T any] interface {
+	scanNamed(column string) (any, func(*T) error)
+}
+
+func (structScanner[T]) scanNamed(column string) (any, func(*T) error) {
+	var t T
+
+	rt := structType(reflect.TypeOf(t))
+	if rt == nil {
+		var discard any
+
+		return &discard, func(*T) error { return nil }
+	}
+
+	index, ok := structFieldsByTag(rt, "db")[column]
+	if !ok {
+		var discard any
+
+		return &discard, func(*T) error { return nil }
+	}
+
+	dest := reflect.New(fieldTypeByIndex(rt, index))
+
+	return dest.Interface(), func(t *T) error {
+		fieldAddrByIndex(reflect.ValueOf(t).Elem(), index).Set(dest.Elem())
+
+		return nil
+	}
+}
+
+// structType returns t's underlying struct type, or nil if t (after
+// dereferencing pointers) is not a struct.
+func structType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return t
+}
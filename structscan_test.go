@@ -0,0 +1,195 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeRows is a minimal driver.Rows backed by an in-memory table, enough
+// to drive Query[T, P].Rows through database/sql without a real driver.
+type fakeRows struct {
+	cols []string
+	data [][]driver.Value
+	pos  int
+}
+
+func (r *fakeRows) Columns() []string { return r.cols }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.data) {
+		return io.EOF
+	}
+
+	copy(dest, r.data[r.pos])
+	r.pos++
+
+	return nil
+}
+
+type fakeConn struct{ rows *fakeRows }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, fmt.Errorf("not implemented") }
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	return c.rows, nil
+}
+
+var (
+	fakeDriverOnce     sync.Once
+	fakeDriverRegistry sync.Map // map[string]*fakeRows
+)
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	v, ok := fakeDriverRegistry.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("esquel: no fake rows registered for %q", name)
+	}
+
+	return &fakeConn{rows: v.(*fakeRows)}, nil
+}
+
+// openFakeDB returns a *sql.DB whose QueryContext always replies with
+// rows, registering the stdlib driver needed to produce a real *sql.Rows.
+func openFakeDB(t *testing.T, rows *fakeRows) *sql.DB {
+	t.Helper()
+
+	fakeDriverOnce.Do(func() {
+		sql.Register("esquel_fake", fakeDriver{})
+	})
+
+	name := t.Name()
+	fakeDriverRegistry.Store(name, rows)
+	t.Cleanup(func() { fakeDriverRegistry.Delete(name) })
+
+	db, err := sql.Open("esquel_fake", name)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+type scanItem struct {
+	ID   int    `db:"id"`
+	Name string `db:"name"`
+}
+
+func selectAllStatement() Statement[any] {
+	return Expr(func(any) (string, []any, error) {
+		return "SELECT id, name FROM items", nil, nil
+	})
+}
+
+func TestQueryPointerToStructScansDistinctRows(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "name"},
+		data: [][]driver.Value{
+			{int64(1), "a"},
+			{int64(2), "b"},
+			{int64(3), "c"},
+		},
+	})
+
+	q := Query[*scanItem, any]{Statement: selectAllStatement()}
+
+	list, err := q.All(context.Background(), db, nil)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	want := []scanItem{{1, "a"}, {2, "b"}, {3, "c"}}
+	if len(list) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(list), len(want))
+	}
+
+	for i, item := range list {
+		if *item != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, *item, want[i])
+		}
+	}
+
+	if list[0] == list[1] || list[1] == list[2] {
+		t.Fatalf("rows alias the same *scanItem instead of each getting its own")
+	}
+}
+
+type Address struct {
+	City string `db:"city"`
+}
+
+type person struct {
+	ID int `db:"id"`
+	*Address
+}
+
+func TestQueryEmbeddedPointerFieldScansDistinctRows(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "city"},
+		data: [][]driver.Value{
+			{int64(1), "NYC"},
+			{int64(2), "LA"},
+		},
+	})
+
+	q := Query[person, any]{Statement: Expr(func(any) (string, []any, error) {
+		return "SELECT id, city FROM people", nil, nil
+	})}
+
+	list, err := q.All(context.Background(), db, nil)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	want := []string{"NYC", "LA"}
+	if len(list) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(list), len(want))
+	}
+
+	for i, item := range list {
+		if item.City != want[i] {
+			t.Errorf("row %d city = %q, want %q", i, item.City, want[i])
+		}
+	}
+
+	if list[0].Address == list[1].Address {
+		t.Fatalf("rows alias the same embedded *Address instead of each getting its own")
+	}
+}
+
+func TestQueryStructScansDistinctRows(t *testing.T) {
+	db := openFakeDB(t, &fakeRows{
+		cols: []string{"id", "name"},
+		data: [][]driver.Value{
+			{int64(1), "a"},
+			{int64(2), "b"},
+		},
+	})
+
+	q := Query[scanItem, any]{Statement: selectAllStatement()}
+
+	list, err := q.All(context.Background(), db, nil)
+	if err != nil {
+		t.Fatalf("All: %v", err)
+	}
+
+	want := []scanItem{{1, "a"}, {2, "b"}}
+	for i, item := range list {
+		if item != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, item, want[i])
+		}
+	}
+}
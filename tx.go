@@ -0,0 +1,163 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// TxRunner is a *sql.Tx-backed Querier and Executor, so any existing
+// Query[T, P] or Exec[P] value composes with a transaction unchanged.
+type TxRunner interface {
+	Querier
+	Executor
+
+	// Savepoint runs fn inside a nested SQL savepoint, rolling back to it
+	// if fn returns an error and releasing it otherwise. A failing
+	// SAVEPOINT or RELEASE statement is returned as is, without a rollback.
+	Savepoint(ctx context.Context, name string, fn func(ctx context.Context, tx TxRunner) error) error
+}
+
+// RetryPolicy configures how RunInTx retries a transaction that failed
+// with a retryable error.
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+	Retryable  func(error) bool
+}
+
+// DefaultRetryPolicy retries up to 3 times with exponential backoff that
+// starts at 20ms and is capped at 1s; each wait is drawn at random between
+// zero and that bound. Retryable errors are classified with IsRetryable.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  20 * time.Millisecond,
+	MaxDelay:   1 * time.Second,
+	Retryable:  IsRetryable,
+}
+
+// RunInTx runs fn inside a transaction opened on db, retrying with
+// DefaultRetryPolicy on a retryable error.
+func RunInTx(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(ctx context.Context, tx TxRunner) error) error {
+	return RunInTxWithPolicy(ctx, db, opts, DefaultRetryPolicy, fn)
+}
+
+// RunInTxWithPolicy is RunInTx with an explicit RetryPolicy.
+func RunInTxWithPolicy(ctx context.Context, db *sql.DB, opts *sql.TxOptions, policy RetryPolicy, fn func(ctx context.Context, tx TxRunner) error) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := backoff(ctx, policy, attempt); err != nil {
+				return err
+			}
+		}
+
+		err := runInTxOnce(ctx, db, opts, fn)
+		if err == nil {
+			return nil
+		}
+
+		lastErr = err
+
+		if policy.Retryable == nil || !policy.Retryable(err) {
+			return err
+		}
+	}
+
+	return lastErr
+}
+
+func runInTxOnce(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(ctx context.Context, tx TxRunner) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(ctx, &txRunner{tx: tx}); err != nil {
+		if rerr := tx.Rollback(); rerr != nil && !errors.Is(rerr, sql.ErrTxDone) {
+			return errors.Join(err, rerr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func backoff(ctx context.Context, policy RetryPolicy, attempt int) error {
+	delay := policy.BaseDelay << (attempt - 1)
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+
+	if delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay) + 1))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+type txRunner struct {
+	tx *sql.Tx
+}
+
+func (r *txRunner) QueryContext(ctx context.Context, sql string, args ...any) (*sql.Rows, error) {
+	return r.tx.QueryContext(ctx, sql, args...)
+}
+
+func (r *txRunner) ExecContext(ctx context.Context, sql string, args ...any) (sql.Result, error) {
+	return r.tx.ExecContext(ctx, sql, args...)
+}
+
+func (r *txRunner) Savepoint(ctx context.Context, name string, fn func(ctx context.Context, tx TxRunner) error) error {
+	if !isValidIdentifier(name) {
+		return fmt.Errorf("esquel: invalid savepoint name %q", name)
+	}
+
+	if _, err := r.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(ctx, r); err != nil {
+		if _, rerr := r.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rerr != nil {
+			return errors.Join(err, rerr)
+		}
+
+		return err
+	}
+
+	if _, err := r.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isValidIdentifier reports whether name is safe to splice directly into
+// a SAVEPOINT statement, since savepoint names can't be bound as args.
+func isValidIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i := 0; i < len(name); i++ {
+		if !isNameByte(name[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,185 @@
+package esquel
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type txFakeTx struct{}
+
+func (txFakeTx) Commit() error   { return nil }
+func (txFakeTx) Rollback() error { return nil }
+
+// txFakeConn is a driver.Conn that only supports what RunInTx and
+// TxRunner.Savepoint need: beginning a transaction and running execs,
+// recording the latter so savepoint tests can assert on them.
+type txFakeConn struct {
+	mu    sync.Mutex
+	execs []string
+}
+
+func (c *txFakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+func (c *txFakeConn) Close() error              { return nil }
+func (c *txFakeConn) Begin() (driver.Tx, error) { return txFakeTx{}, nil }
+
+func (c *txFakeConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return txFakeTx{}, nil
+}
+
+func (c *txFakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.mu.Lock()
+	c.execs = append(c.execs, query)
+	c.mu.Unlock()
+
+	return driver.RowsAffected(0), nil
+}
+
+var (
+	txFakeDriverOnce     sync.Once
+	txFakeDriverRegistry sync.Map // map[string]*txFakeConn
+)
+
+type txFakeDriver struct{}
+
+func (txFakeDriver) Open(name string) (driver.Conn, error) {
+	v, ok := txFakeDriverRegistry.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("esquel: no fake conn registered for %q", name)
+	}
+
+	return v.(*txFakeConn), nil
+}
+
+func openTxFakeDB(t *testing.T) (*sql.DB, *txFakeConn) {
+	t.Helper()
+
+	txFakeDriverOnce.Do(func() {
+		sql.Register("esquel_tx_fake", txFakeDriver{})
+	})
+
+	conn := &txFakeConn{}
+	name := t.Name()
+	txFakeDriverRegistry.Store(name, conn)
+	t.Cleanup(func() { txFakeDriverRegistry.Delete(name) })
+
+	db, err := sql.Open("esquel_tx_fake", name)
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err)
+	}
+
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	return db, conn
+}
+
+// lockedErr mimics SQLite's "database is locked" message, which
+// IsRetryable recognizes as a transient, retryable failure.
+type lockedErr struct{}
+
+func (lockedErr) Error() string { return "database is locked" }
+
+func TestRunInTxWithPolicyRetriesRetryableErrors(t *testing.T) {
+	db, _ := openTxFakeDB(t)
+
+	var attempts int
+
+	policy := RetryPolicy{
+		MaxRetries: 2,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   time.Millisecond,
+		Retryable:  IsRetryable,
+	}
+
+	err := RunInTxWithPolicy(context.Background(), db, nil, policy, func(ctx context.Context, tx TxRunner) error {
+		attempts++
+
+		if attempts < 3 {
+			return lockedErr{}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunInTxWithPolicy: %v", err)
+	}
+
+	if attempts != 3 {
+		t.Fatalf("attempts = %d, want 3", attempts)
+	}
+}
+
+func TestRunInTxWithPolicyStopsOnNonRetryableError(t *testing.T) {
+	db, _ := openTxFakeDB(t)
+
+	var attempts int
+
+	wantErr := errors.New("boom")
+
+	err := RunInTxWithPolicy(context.Background(), db, nil, DefaultRetryPolicy, func(ctx context.Context, tx TxRunner) error {
+		attempts++
+
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	if attempts != 1 {
+		t.Fatalf("attempts = %d, want 1: a non-retryable error must not be retried", attempts)
+	}
+}
+
+func TestSavepointRollsBackOnError(t *testing.T) {
+	db, conn := openTxFakeDB(t)
+
+	wantErr := errors.New("inner failure")
+
+	err := RunInTx(context.Background(), db, nil, func(ctx context.Context, tx TxRunner) error {
+		return tx.Savepoint(ctx, "sp1", func(ctx context.Context, tx TxRunner) error {
+			return wantErr
+		})
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want %v", err, wantErr)
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	want := []string{"SAVEPOINT sp1", "ROLLBACK TO SAVEPOINT sp1"}
+
+	if len(conn.execs) != len(want) || conn.execs[0] != want[0] || conn.execs[1] != want[1] {
+		t.Fatalf("execs = %v, want %v", conn.execs, want)
+	}
+}
+
+func TestSavepointReleasesOnSuccess(t *testing.T) {
+	db, conn := openTxFakeDB(t)
+
+	err := RunInTx(context.Background(), db, nil, func(ctx context.Context, tx TxRunner) error {
+		return tx.Savepoint(ctx, "sp1", func(ctx context.Context, tx TxRunner) error {
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatalf("RunInTx: %v", err)
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	want := []string{"SAVEPOINT sp1", "RELEASE SAVEPOINT sp1"}
+
+	if len(conn.execs) != len(want) || conn.execs[0] != want[0] || conn.execs[1] != want[1] {
+		t.Fatalf("execs = %v, want %v", conn.execs, want)
+	}
+}